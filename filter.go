@@ -54,13 +54,20 @@ type excludeFnFS struct {
 	hide func(string) bool
 }
 
-func (f *excludeFnFS) Open(name string) (fs.File, error) {
-	pfxs := pathPrefixes(name)
-	for _, pfx := range pfxs {
+// hidden reports whether name or any of its ancestor directories is hidden.
+func (f *excludeFnFS) hidden(name string) bool {
+	for _, pfx := range pathPrefixes(name) {
 		if f.hide(pfx) {
-			return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+			return true
 		}
 	}
+	return false
+}
+
+func (f *excludeFnFS) Open(name string) (fs.File, error) {
+	if f.hidden(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
 	file, err := f.fsys.Open(name)
 	if err != nil {
 		return nil, err
@@ -83,6 +90,12 @@ type excludeFnDir struct {
 	fs.ReadDirFile
 }
 
+// Stat disambiguates the Stat promoted from fs.ReadDirFile (fs.File's Stat)
+// from the unrelated excludeFnFS.Stat(name string) added for fs.StatFS.
+func (f *excludeFnDir) Stat() (fs.FileInfo, error) {
+	return f.ReadDirFile.Stat()
+}
+
 func (f *excludeFnDir) ReadDir(n int) ([]fs.DirEntry, error) {
 	des, err := f.ReadDirFile.ReadDir(n)
 	if err != nil {
@@ -108,11 +121,53 @@ func (f *excludeFnDir) ReadDir(n int) ([]fs.DirEntry, error) {
 	return des, nil
 }
 
-// TODO: Add other extension methods.
-// These require extra care to ensure that hiding a directory
-// also hides all contained subdirectories and files.
+// Stat implements fs.StatFS, hiding name the same way Open does.
+func (f *excludeFnFS) Stat(name string) (fs.FileInfo, error) {
+	if f.hidden(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return fs.Stat(f.fsys, name)
+}
+
+// ReadFile implements fs.ReadFileFS, hiding name the same way Open does.
+func (f *excludeFnFS) ReadFile(name string) ([]byte, error) {
+	if f.hidden(name) {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: fs.ErrNotExist}
+	}
+	return fs.ReadFile(f.fsys, name)
+}
+
+// Glob implements fs.GlobFS, omitting any match hidden the same way Open hides it.
+func (f *excludeFnFS) Glob(pattern string) ([]string, error) {
+	matches, err := fs.Glob(f.fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var dst int
+	for _, m := range matches {
+		if f.hidden(m) {
+			continue
+		}
+		matches[dst] = m
+		dst++
+	}
+	return matches[:dst], nil
+}
 
-// func (f *excludeFnFS) Glob(pattern string) ([]string, error)
-// func (f *excludeFnFS) ReadFile(name string) ([]byte, error)
-// func (f *excludeFnFS) Stat(name string) (fs.FileInfo, error)
-// func (f *excludeFnFS) Sub(dir string) (fs.FS, error)
+// Sub implements fs.SubFS. The returned filesystem re-prefixes hide with dir,
+// so that paths hidden in f remain hidden when addressed relative to dir.
+func (f *excludeFnFS) Sub(dir string) (fs.FS, error) {
+	if f.hidden(dir) {
+		return nil, &fs.PathError{Op: "sub", Path: dir, Err: fs.ErrNotExist}
+	}
+	sub, err := fs.Sub(f.fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	hide := f.hide
+	return &excludeFnFS{
+		fsys: sub,
+		hide: func(name string) bool { return hide(filepath.Join(dir, name)) },
+	}, nil
+}
@@ -8,14 +8,18 @@ import (
 	"testing/fstest"
 )
 
-func TestExcludePaths(t *testing.T) {
-	mapfs := fstest.MapFS{
+func excludeTestFS() fstest.MapFS {
+	return fstest.MapFS{
 		"a":     &fstest.MapFile{Data: []byte{'a'}},
 		"b/c":   &fstest.MapFile{},
 		"d":     &fstest.MapFile{Data: []byte{'d'}},
 		"e/f":   &fstest.MapFile{Data: []byte{'f'}},
 		"g/h/i": &fstest.MapFile{Data: []byte{'i'}},
 	}
+}
+
+func TestExcludePaths(t *testing.T) {
+	mapfs := excludeTestFS()
 
 	hfs := ExcludePaths(mapfs, "b", "f", "g/h")
 	err := fstest.TestFS(hfs, "a", "d", "e", "g")
@@ -31,6 +35,58 @@ func TestExcludePaths(t *testing.T) {
 	}
 }
 
+func TestExcludeFnStat(t *testing.T) {
+	hfs := ExcludePaths(excludeTestFS(), "b", "g/h")
+
+	if _, err := fs.Stat(hfs, "a"); err != nil {
+		t.Errorf("Stat(a) = %v, want nil", err)
+	}
+	for _, exclude := range []string{"b", "b/c", "g/h", "g/h/i"} {
+		if _, err := fs.Stat(hfs, exclude); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Stat(%s) = %v, want fs.ErrNotExist", exclude, err)
+		}
+	}
+}
+
+func TestExcludeFnReadFile(t *testing.T) {
+	hfs := ExcludePaths(excludeTestFS(), "b", "g/h")
+
+	data, err := fs.ReadFile(hfs, "a")
+	if err != nil || string(data) != "a" {
+		t.Errorf("ReadFile(a) = %q, %v, want \"a\", nil", data, err)
+	}
+	if _, err := fs.ReadFile(hfs, "g/h/i"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadFile(g/h/i) = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestExcludeFnGlob(t *testing.T) {
+	hfs := ExcludePaths(excludeTestFS(), "g/h")
+
+	matches, err := fs.Glob(hfs, "g/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(matches, []string{}) && len(matches) != 0 {
+		t.Errorf("Glob(g/*) = %v, want no matches under the hidden g/h", matches)
+	}
+}
+
+func TestExcludeFnSub(t *testing.T) {
+	hfs := ExcludePaths(excludeTestFS(), "g/h")
+
+	sub, err := fs.Sub(hfs, "g")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fstest.TestFS(sub); err != nil {
+		t.Error(err)
+	}
+	if _, err := fs.Stat(sub, "h"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat(h) in sub-fs = %v, want fs.ErrNotExist", err)
+	}
+}
+
 func TestPathPrefixes(t *testing.T) {
 	tests := []struct {
 		in   string
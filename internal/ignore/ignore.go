@@ -0,0 +1,181 @@
+// Package ignore implements gitignore-style pattern matching, as used by
+// filterfs.ExcludePatterns and by tools such as gocryptfs's --exclude-wildcard.
+//
+// Patterns are evaluated in order against a path and all of its ancestor
+// directories: a leading "/" anchors the pattern to the filesystem root, a
+// trailing "/" restricts it to directories, "*" matches within a single path
+// segment, "**" matches across segments, "!" re-includes a path excluded by
+// an earlier pattern, and "#" starts a comment. A pattern with no slash (other
+// than a trailing one) matches at any depth, mirroring a bare name in a
+// .gitignore file.
+package ignore
+
+import (
+	"path"
+	"strings"
+)
+
+// Matcher evaluates an ordered list of gitignore-style patterns.
+type Matcher struct {
+	patterns []pattern
+}
+
+type pattern struct {
+	negate   bool
+	dirOnly  bool
+	anchored bool
+	segs     []string
+}
+
+// New parses patterns into a Matcher. Blank lines and lines starting with "#"
+// are ignored, matching gitignore's comment syntax.
+func New(patterns ...string) *Matcher {
+	m := &Matcher{}
+	for _, raw := range patterns {
+		if p, ok := parsePattern(raw); ok {
+			m.patterns = append(m.patterns, p)
+		}
+	}
+	return m
+}
+
+func parsePattern(raw string) (pattern, bool) {
+	line := raw
+	if line == "" || strings.HasPrefix(line, "#") {
+		return pattern{}, false
+	}
+
+	var p pattern
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	}
+	if strings.HasPrefix(line, "/") {
+		p.anchored = true
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	if line == "" {
+		return pattern{}, false
+	}
+
+	p.segs = strings.Split(line, "/")
+	if len(p.segs) > 1 {
+		// An internal slash anchors the pattern to the root, same as a leading one.
+		p.anchored = true
+	}
+	return p, true
+}
+
+// Match reports whether path is excluded by m, considering path itself and
+// every ancestor directory of path. isDir indicates whether path names a
+// directory.
+func (m *Matcher) Match(p string, isDir bool) bool {
+	if p == "." {
+		return false
+	}
+	segs := strings.Split(path.Clean(p), "/")
+
+	excluded := false
+	for _, pat := range m.patterns {
+		if pat.matchesPathOrAncestor(segs, isDir) {
+			excluded = !pat.negate
+		}
+	}
+	return excluded
+}
+
+// MayIncludeWithin reports whether some path inside the directory dir could
+// possibly be re-included by a "!" pattern, even though dir itself is
+// excluded. Callers use this to decide whether an excluded directory must
+// still be opened and descended into, rather than hidden outright.
+func (m *Matcher) MayIncludeWithin(dir string) bool {
+	var dirSegs []string
+	if dir != "." {
+		dirSegs = strings.Split(path.Clean(dir), "/")
+	}
+
+	for _, pat := range m.patterns {
+		if !pat.negate {
+			continue
+		}
+		if !pat.anchored {
+			// An unanchored pattern can match at any depth, including inside dir.
+			return true
+		}
+
+		segs := pat.segs
+		ok, reachesDeeper := true, false
+		for i := 0; i < len(dirSegs); i++ {
+			if i >= len(segs) {
+				ok = false
+				break
+			}
+			if segs[i] == "**" {
+				reachesDeeper = true
+				break
+			}
+			if matched, err := path.Match(segs[i], dirSegs[i]); err != nil || !matched {
+				ok = false
+				break
+			}
+		}
+		if ok && (reachesDeeper || len(segs) > len(dirSegs)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (pat pattern) matchesPathOrAncestor(segs []string, isDir bool) bool {
+	if pat.matches(segs, isDir) {
+		return true
+	}
+	// Ancestor directories are matched too: excluding a directory excludes
+	// everything beneath it, regardless of that pattern's dirOnly flag.
+	for i := 1; i < len(segs); i++ {
+		if pat.matches(segs[:i], true) {
+			return true
+		}
+	}
+	return false
+}
+
+func (pat pattern) matches(segs []string, isDir bool) bool {
+	if pat.dirOnly && !isDir {
+		return false
+	}
+	want := pat.segs
+	if !pat.anchored {
+		want = append([]string{"**"}, pat.segs...)
+	}
+	return matchSegs(want, segs)
+}
+
+// matchSegs matches pattern segments against path segments, treating "**" as
+// matching zero or more whole path segments and other segments as
+// path.Match-style globs.
+func matchSegs(pat, segs []string) bool {
+	if len(pat) == 0 {
+		return len(segs) == 0
+	}
+	if pat[0] == "**" {
+		for i := 0; i <= len(segs); i++ {
+			if matchSegs(pat[1:], segs[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segs) == 0 {
+		return false
+	}
+	ok, err := path.Match(pat[0], segs[0])
+	if err != nil || !ok {
+		return false
+	}
+	return matchSegs(pat[1:], segs[1:])
+}
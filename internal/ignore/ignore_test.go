@@ -0,0 +1,197 @@
+package ignore
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		path     string
+		isDir    bool
+		want     bool
+	}{
+		{
+			name:     "bare name matches any depth",
+			patterns: []string{"foo"},
+			path:     "a/b/foo",
+			isDir:    false,
+			want:     true,
+		},
+		{
+			name:     "bare name matches at root too",
+			patterns: []string{"foo"},
+			path:     "foo",
+			isDir:    false,
+			want:     true,
+		},
+		{
+			name:     "anchored pattern only matches at root",
+			patterns: []string{"/foo"},
+			path:     "a/foo",
+			isDir:    false,
+			want:     false,
+		},
+		{
+			name:     "anchored pattern matches at root",
+			patterns: []string{"/foo"},
+			path:     "foo",
+			isDir:    false,
+			want:     true,
+		},
+		{
+			name:     "internal slash anchors implicitly",
+			patterns: []string{"a/foo"},
+			path:     "x/a/foo",
+			isDir:    false,
+			want:     false,
+		},
+		{
+			name:     "trailing slash matches only directories",
+			patterns: []string{"build/"},
+			path:     "build",
+			isDir:    false,
+			want:     false,
+		},
+		{
+			name:     "trailing slash matches the directory",
+			patterns: []string{"build/"},
+			path:     "build",
+			isDir:    true,
+			want:     true,
+		},
+		{
+			name:     "directory match hides descendants",
+			patterns: []string{"build/"},
+			path:     "build/out.o",
+			isDir:    false,
+			want:     true,
+		},
+		{
+			name:     "star matches within a segment only",
+			patterns: []string{"*.o"},
+			path:     "a/b.o",
+			isDir:    false,
+			want:     true,
+		},
+		{
+			name:     "star does not cross a segment boundary",
+			patterns: []string{"a*c"},
+			path:     "a/c",
+			isDir:    false,
+			want:     false,
+		},
+		{
+			name:     "double star crosses segment boundaries",
+			patterns: []string{"a/**/c"},
+			path:     "a/b/d/c",
+			isDir:    false,
+			want:     true,
+		},
+		{
+			name:     "double star matches zero segments",
+			patterns: []string{"a/**/c"},
+			path:     "a/c",
+			isDir:    false,
+			want:     true,
+		},
+		{
+			name:     "negation re-includes a path excluded by an earlier pattern",
+			patterns: []string{"*.log", "!keep.log"},
+			path:     "keep.log",
+			isDir:    false,
+			want:     false,
+		},
+		{
+			name:     "later pattern overrides an earlier negation",
+			patterns: []string{"!keep.log", "*.log"},
+			path:     "keep.log",
+			isDir:    false,
+			want:     true,
+		},
+		{
+			name:     "negation of a file inside an excluded dir re-includes it",
+			patterns: []string{"dir/", "!dir/keep.txt"},
+			path:     "dir/keep.txt",
+			isDir:    false,
+			want:     false,
+		},
+		{
+			name:     "sibling in an excluded dir stays excluded",
+			patterns: []string{"dir/", "!dir/keep.txt"},
+			path:     "dir/other.txt",
+			isDir:    false,
+			want:     true,
+		},
+		{
+			name:     "root is never excluded",
+			patterns: []string{"**"},
+			path:     ".",
+			isDir:    true,
+			want:     false,
+		},
+		{
+			name:     "comments and blank lines are ignored",
+			patterns: []string{"# comment", "", "foo"},
+			path:     "foo",
+			isDir:    false,
+			want:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(tt.patterns...)
+			if got := m.Match(tt.path, tt.isDir); got != tt.want {
+				t.Errorf("New(%v).Match(%q, %v) = %v, want %v", tt.patterns, tt.path, tt.isDir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMayIncludeWithin(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		dir      string
+		want     bool
+	}{
+		{
+			name:     "no negation at all",
+			patterns: []string{"build/"},
+			dir:      "build",
+			want:     false,
+		},
+		{
+			name:     "unrelated anchored negation",
+			patterns: []string{"build/", "!dir/keep.txt"},
+			dir:      "build",
+			want:     false,
+		},
+		{
+			name:     "negation targets a descendant",
+			patterns: []string{"dir/", "!dir/keep.txt"},
+			dir:      "dir",
+			want:     true,
+		},
+		{
+			name:     "negation targets an ancestor, not a descendant",
+			patterns: []string{"dir/", "!/dir"},
+			dir:      "dir/sub",
+			want:     false,
+		},
+		{
+			name:     "unanchored negation could match at any depth",
+			patterns: []string{"dir/", "!keep.txt"},
+			dir:      "dir",
+			want:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.patterns...).MayIncludeWithin(tt.dir); got != tt.want {
+				t.Errorf("MayIncludeWithin(%q) = %v, want %v", tt.dir, got, tt.want)
+			}
+		})
+	}
+}
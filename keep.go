@@ -0,0 +1,109 @@
+package filterfs
+
+import (
+	"io/fs"
+	"path/filepath"
+)
+
+// KeepPaths returns a filesystem identical to fsys containing only the paths in keep.
+// A directory is kept if it, or some descendant of it, is in keep, so that
+// descent toward a kept path continues to work; a directory with no kept
+// descendant is pruned entirely, as in shurcooL/httpfs's filter.Keep.
+func KeepPaths(fsys fs.FS, keep ...string) fs.FS {
+	kept := make(map[string]bool, len(keep))
+	for _, path := range keep {
+		kept[path] = true
+	}
+	return KeepFn(fsys, func(s string) bool { return kept[s] })
+}
+
+// KeepFn returns a filesystem identical to fsys containing only paths for which keep(path) returns true.
+// A directory is kept if keep(path) is true for it, or for some descendant of
+// it, so that descent toward a kept path continues to work; a directory with
+// no kept descendant is pruned entirely, as in shurcooL/httpfs's filter.Keep.
+func KeepFn(fsys fs.FS, keep func(string) bool) fs.FS {
+	return &keepFnFS{fsys: fsys, keep: keep}
+}
+
+type keepFnFS struct {
+	fsys fs.FS
+	keep func(string) bool
+}
+
+func (f *keepFnFS) Open(name string) (fs.File, error) {
+	file, err := f.fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		if name != "." && !f.keep(name) && !hasKeptDescendant(f.fsys, f.keep, name) {
+			file.Close()
+			return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+		}
+		if rdf, ok := file.(fs.ReadDirFile); ok {
+			return &keepFnDir{path: name, keepFnFS: f, ReadDirFile: rdf}, nil
+		}
+		return file, nil
+	}
+	if !f.keep(name) {
+		file.Close()
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return file, nil
+}
+
+// hasKeptDescendant reports whether keep is true for some path within dir,
+// walking fsys (not the keepFnFS wrapper, to avoid recursing through itself).
+func hasKeptDescendant(fsys fs.FS, keep func(string) bool, dir string) bool {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return false
+	}
+	for _, de := range entries {
+		path := filepath.Clean(dir + "/" + de.Name())
+		if keep(path) {
+			return true
+		}
+		if de.IsDir() && hasKeptDescendant(fsys, keep, path) {
+			return true
+		}
+	}
+	return false
+}
+
+type keepFnDir struct {
+	path string
+	*keepFnFS
+	fs.ReadDirFile
+}
+
+func (f *keepFnDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	des, err := f.ReadDirFile.ReadDir(n)
+	if err != nil {
+		return nil, err
+	}
+
+	var dst int
+	for _, de := range des {
+		path := filepath.Clean(f.path + "/" + de.Name())
+		if !f.keep(path) {
+			if !de.IsDir() || !hasKeptDescendant(f.fsys, f.keep, path) {
+				continue
+			}
+		}
+		des[dst] = de
+		dst++
+	}
+
+	tail := des[dst:]
+	des = des[:dst]
+	for i := range tail {
+		tail[i] = nil
+	}
+
+	return des, nil
+}
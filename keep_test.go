@@ -0,0 +1,96 @@
+package filterfs
+
+import (
+	"errors"
+	"io/fs"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestKeepPaths(t *testing.T) {
+	mapfs := fstest.MapFS{
+		"a":     &fstest.MapFile{Data: []byte{'a'}},
+		"b/c":   &fstest.MapFile{},
+		"d":     &fstest.MapFile{Data: []byte{'d'}},
+		"e/f":   &fstest.MapFile{Data: []byte{'f'}},
+		"g/h/i": &fstest.MapFile{Data: []byte{'i'}},
+	}
+
+	kfs := KeepPaths(mapfs, "a", "g/h/i")
+	err := fstest.TestFS(kfs, "a", "g/h/i")
+	if err != nil {
+		t.Error(err)
+	}
+
+	for _, keep := range []string{"a", "g", "g/h", "g/h/i"} {
+		if _, err := fs.Stat(kfs, keep); err != nil {
+			t.Errorf("fsys missing kept path %s: %v", keep, err)
+		}
+	}
+
+	for _, drop := range []string{"b", "b/c", "d", "e", "e/f"} {
+		_, err := fs.Stat(kfs, drop)
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("fsys contains unkept path %s", drop)
+		}
+	}
+
+	entries, err := fs.ReadDir(kfs, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, de := range entries {
+		names = append(names, de.Name())
+	}
+	if want := []string{"a", "g"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ReadDir(.) = %v, want %v", names, want)
+	}
+}
+
+func TestKeepPathsPrunesEmptyDirs(t *testing.T) {
+	mapfs := fstest.MapFS{
+		"a":   &fstest.MapFile{Data: []byte{'a'}},
+		"b/c": &fstest.MapFile{},
+		"e/f": &fstest.MapFile{},
+	}
+
+	kfs := KeepPaths(mapfs, "a")
+
+	for _, drop := range []string{"b", "b/c", "e", "e/f"} {
+		if _, err := fs.Stat(kfs, drop); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("fsys contains dir with no kept descendant: %s", drop)
+		}
+	}
+}
+
+func TestKeepPathsKeepsSubtree(t *testing.T) {
+	mapfs := fstest.MapFS{
+		"docs/a.md": &fstest.MapFile{Data: []byte("a")},
+		"docs/b.md": &fstest.MapFile{Data: []byte("b")},
+		"src/a.go":  &fstest.MapFile{},
+	}
+
+	kfs := KeepPaths(mapfs, "docs/a.md", "docs/b.md")
+
+	for _, keep := range []string{"docs", "docs/a.md", "docs/b.md"} {
+		if _, err := fs.Stat(kfs, keep); err != nil {
+			t.Errorf("fsys missing kept path %s: %v", keep, err)
+		}
+	}
+	if _, err := fs.Stat(kfs, "src"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("fsys contains dir with no kept descendant: src")
+	}
+}
+
+func TestKeepPathsRoot(t *testing.T) {
+	mapfs := fstest.MapFS{
+		"a": &fstest.MapFile{Data: []byte{'a'}},
+	}
+
+	kfs := KeepPaths(mapfs, "a")
+	if _, err := fs.Stat(kfs, "."); err != nil {
+		t.Errorf("root must never be filtered out: %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package filterfs
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// ExcludeNames returns a filesystem identical to fsys excluding paths matched
+// by names, mirroring the file-hiding idiom used by Caddy's fileserver. A
+// bare name (no path separator) hides any file or directory with that
+// basename at any depth, e.g. ".git" hides both ".git" and "foo/.git/bar".
+// A name containing a path separator hides only that exact path and its
+// subtree, e.g. "foo/.git" hides "foo/.git" and "foo/.git/x" but not sibling
+// names sharing a prefix, such as "foo/.gitignore".
+// ExcludeNames panics if a bare name would hide the root path ".".
+func ExcludeNames(fsys fs.FS, names ...string) fs.FS {
+	bare := make(map[string]bool)
+	exact := make(map[string]bool)
+	for _, name := range names {
+		if strings.Contains(name, "/") {
+			exact[filepath.Clean(name)] = true
+		} else {
+			bare[name] = true
+		}
+	}
+	return ExcludeFn(fsys, func(path string) bool {
+		return bare[filepath.Base(path)] || exact[path]
+	})
+}
@@ -0,0 +1,50 @@
+package filterfs
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func TestExcludeNames(t *testing.T) {
+	mapfs := fstest.MapFS{
+		"a.txt":             &fstest.MapFile{},
+		".git/HEAD":         &fstest.MapFile{},
+		"foo/.git/config":   &fstest.MapFile{},
+		"foo/.gitignore":    &fstest.MapFile{},
+		"bar/baz/.git/HEAD": &fstest.MapFile{},
+	}
+
+	nfs := ExcludeNames(mapfs, ".git")
+
+	err := fstest.TestFS(nfs, "a.txt", "foo/.gitignore")
+	if err != nil {
+		t.Error(err)
+	}
+
+	for _, exclude := range []string{".git", ".git/HEAD", "foo/.git", "foo/.git/config", "bar/baz/.git"} {
+		if _, err := fs.Stat(nfs, exclude); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("fsys contains excluded path %s", exclude)
+		}
+	}
+	if _, err := fs.Stat(nfs, "foo/.gitignore"); err != nil {
+		t.Errorf("fsys missing kept path foo/.gitignore: %v", err)
+	}
+}
+
+func TestExcludeNamesExactPath(t *testing.T) {
+	mapfs := fstest.MapFS{
+		"foo/.git/config": &fstest.MapFile{},
+		"foo/.gitignore":  &fstest.MapFile{},
+	}
+
+	nfs := ExcludeNames(mapfs, "foo/.git")
+
+	if _, err := fs.Stat(nfs, "foo/.git/config"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("fsys contains excluded path foo/.git/config")
+	}
+	if _, err := fs.Stat(nfs, "foo/.gitignore"); err != nil {
+		t.Errorf("fsys missing sibling path foo/.gitignore: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package filterfs
+
+import (
+	"io/fs"
+	"path/filepath"
+
+	"github.com/josharian/filterfs/internal/ignore"
+)
+
+// ExcludePatterns returns a filesystem identical to fsys excluding paths matched
+// by patterns, using gitignore syntax: a leading "/" anchors a pattern to the
+// filesystem root, a trailing "/" restricts it to directories, "*" and "**"
+// are wildcards, "!" re-includes a path excluded by an earlier pattern, and
+// "#" starts a comment. Patterns are evaluated in order, as in a .gitignore
+// file. A directory matched by a pattern hides its entire subtree, unless some
+// "!" pattern could match a path inside it, in which case the directory itself
+// remains traversable. That check does not consider whether the "!" pattern
+// comes before or after the pattern matching the directory, nor whether any
+// path inside the directory actually matches it, so such a directory is listed
+// even when none of its contents are re-included. This mirrors the semantics
+// of gocryptfs's --exclude-wildcard.
+func ExcludePatterns(fsys fs.FS, patterns ...string) fs.FS {
+	return &patternsFS{fsys: fsys, m: ignore.New(patterns...)}
+}
+
+type patternsFS struct {
+	fsys fs.FS
+	m    *ignore.Matcher
+}
+
+func (f *patternsFS) Open(name string) (fs.File, error) {
+	file, err := f.fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		if f.m.Match(name, true) && !f.m.MayIncludeWithin(name) {
+			file.Close()
+			return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+		}
+		if rdf, ok := file.(fs.ReadDirFile); ok {
+			return &patternsDir{path: name, patternsFS: f, ReadDirFile: rdf}, nil
+		}
+		return file, nil
+	}
+	if f.m.Match(name, false) {
+		file.Close()
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return file, nil
+}
+
+type patternsDir struct {
+	path string
+	*patternsFS
+	fs.ReadDirFile
+}
+
+func (f *patternsDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	des, err := f.ReadDirFile.ReadDir(n)
+	if err != nil {
+		return nil, err
+	}
+
+	var dst int
+	for _, de := range des {
+		path := filepath.Clean(f.path + "/" + de.Name())
+		if f.m.Match(path, de.IsDir()) && (!de.IsDir() || !f.m.MayIncludeWithin(path)) {
+			continue
+		}
+		des[dst] = de
+		dst++
+	}
+
+	tail := des[dst:]
+	des = des[:dst]
+	for i := range tail {
+		tail[i] = nil
+	}
+
+	return des, nil
+}
@@ -0,0 +1,50 @@
+package filterfs
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func TestExcludePatterns(t *testing.T) {
+	mapfs := fstest.MapFS{
+		"a.txt":       &fstest.MapFile{},
+		"b.log":       &fstest.MapFile{},
+		"build/out.o": &fstest.MapFile{},
+		"dir/keep.txt": &fstest.MapFile{
+			Data: []byte("keep"),
+		},
+		"dir/other.txt": &fstest.MapFile{},
+	}
+
+	pfs := ExcludePatterns(mapfs, "*.log", "build/", "dir/", "!dir/keep.txt")
+
+	err := fstest.TestFS(pfs, "a.txt", "dir/keep.txt")
+	if err != nil {
+		t.Error(err)
+	}
+
+	for _, exclude := range []string{"b.log", "build", "build/out.o", "dir/other.txt"} {
+		if _, err := fs.Stat(pfs, exclude); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("fsys contains excluded path %s", exclude)
+		}
+	}
+
+	for _, keep := range []string{"a.txt", "dir", "dir/keep.txt"} {
+		if _, err := fs.Stat(pfs, keep); err != nil {
+			t.Errorf("fsys missing kept path %s: %v", keep, err)
+		}
+	}
+}
+
+func TestExcludePatternsWithoutNegationPrunesSubtree(t *testing.T) {
+	mapfs := fstest.MapFS{
+		"build/out.o": &fstest.MapFile{},
+	}
+
+	pfs := ExcludePatterns(mapfs, "build/")
+	if _, err := fs.Stat(pfs, "build"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("fsys contains excluded directory build: %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+package filterfs
+
+import (
+	"io/fs"
+	"regexp"
+)
+
+// ExcludeRegexp returns a filesystem identical to fsys excluding paths matched
+// by re, analogous to afero's RegexpFs but matching against the full path
+// rather than just the base name. Hiding a directory hides all contained
+// subdirectories and files.
+// ExcludeRegexp panics if re matches ".".
+func ExcludeRegexp(fsys fs.FS, re *regexp.Regexp) fs.FS {
+	return ExcludeFn(fsys, re.MatchString)
+}
+
+// KeepRegexp returns a filesystem identical to fsys containing only paths
+// matched by re, analogous to afero's RegexpFs but matching against the full
+// path rather than just the base name. A directory is kept only if it or
+// some descendant matches re; directories with no matching descendant are
+// pruned, while intermediate directories leading to a match remain traversable.
+func KeepRegexp(fsys fs.FS, re *regexp.Regexp) fs.FS {
+	return KeepFn(fsys, re.MatchString)
+}
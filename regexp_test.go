@@ -0,0 +1,54 @@
+package filterfs
+
+import (
+	"errors"
+	"io/fs"
+	"regexp"
+	"testing"
+	"testing/fstest"
+)
+
+func TestExcludeRegexp(t *testing.T) {
+	mapfs := fstest.MapFS{
+		"a.go":        &fstest.MapFile{},
+		"a_test.go":   &fstest.MapFile{},
+		"b/c_test.go": &fstest.MapFile{},
+	}
+
+	rfs := ExcludeRegexp(mapfs, regexp.MustCompile(`_test\.go$`))
+	err := fstest.TestFS(rfs, "a.go", "b")
+	if err != nil {
+		t.Error(err)
+	}
+
+	for _, exclude := range []string{"a_test.go", "b/c_test.go"} {
+		if _, err := fs.Stat(rfs, exclude); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("fsys contains excluded file %s", exclude)
+		}
+	}
+}
+
+func TestKeepRegexp(t *testing.T) {
+	mapfs := fstest.MapFS{
+		"a.go":        &fstest.MapFile{},
+		"a_test.go":   &fstest.MapFile{},
+		"b/c_test.go": &fstest.MapFile{},
+		"c/d.go":      &fstest.MapFile{},
+	}
+
+	rfs := KeepRegexp(mapfs, regexp.MustCompile(`_test\.go$`))
+	err := fstest.TestFS(rfs, "a_test.go", "b/c_test.go")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if _, err := fs.Stat(rfs, "a.go"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("fsys contains unkept file a.go")
+	}
+	if _, err := fs.Stat(rfs, "b"); err != nil {
+		t.Errorf("fsys missing traversable directory b: %v", err)
+	}
+	if _, err := fs.Stat(rfs, "c"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("fsys contains dir c with no kept descendant: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package filterfs
+
+import "io/fs"
+
+// WritableFile is a file returned by WritableFS.Create: a regular fs.File
+// that can also be written to.
+type WritableFile interface {
+	fs.File
+	Write(p []byte) (n int, err error)
+}
+
+// WritableFS is the minimal set of mutating filesystem operations that
+// filterfs can guard with a filter, layered on top of fs.FS. It mirrors the
+// read/write split used by afero's FilePredicateFs and OPA's loader.
+type WritableFS interface {
+	fs.FS
+	Create(name string) (WritableFile, error)
+	Mkdir(name string, perm fs.FileMode) error
+	Remove(name string) error
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+}
+
+// ExcludeFnWritable returns a WritableFS identical to fsys excluding paths for
+// which hide(path) returns true, for both reads and writes: creating,
+// removing, or writing a hidden path fails with fs.ErrNotExist, and reads
+// behave as in ExcludeFn. Hiding a directory hides all contained
+// subdirectories and files.
+// ExcludeFnWritable panics if hide(".") returns true.
+func ExcludeFnWritable(fsys WritableFS, hide func(string) bool) WritableFS {
+	if hide(".") {
+		panic(`ExcludeFnWritable: cannot hide path "."`)
+	}
+	return &writableFnFS{excludeFnFS: &excludeFnFS{fsys: fsys, hide: hide}, fsys: fsys}
+}
+
+type writableFnFS struct {
+	*excludeFnFS
+	fsys WritableFS
+}
+
+func (f *writableFnFS) Create(name string) (WritableFile, error) {
+	if f.hidden(name) {
+		return nil, &fs.PathError{Op: "create", Path: name, Err: fs.ErrNotExist}
+	}
+	return f.fsys.Create(name)
+}
+
+func (f *writableFnFS) Mkdir(name string, perm fs.FileMode) error {
+	if f.hidden(name) {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrNotExist}
+	}
+	return f.fsys.Mkdir(name, perm)
+}
+
+func (f *writableFnFS) Remove(name string) error {
+	if f.hidden(name) {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	return f.fsys.Remove(name)
+}
+
+func (f *writableFnFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if f.hidden(name) {
+		return &fs.PathError{Op: "writefile", Path: name, Err: fs.ErrNotExist}
+	}
+	return f.fsys.WriteFile(name, data, perm)
+}
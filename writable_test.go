@@ -0,0 +1,97 @@
+package filterfs
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+// memWritableFS is a minimal in-memory WritableFS used only to test
+// ExcludeFnWritable.
+type memWritableFS struct {
+	files map[string][]byte
+}
+
+func newMemWritableFS() *memWritableFS {
+	return &memWritableFS{files: map[string][]byte{}}
+}
+
+func (m *memWritableFS) Open(name string) (fs.File, error) {
+	mfs := fstest.MapFS{}
+	for path, data := range m.files {
+		mfs[path] = &fstest.MapFile{Data: data}
+	}
+	return mfs.Open(name)
+}
+
+func (m *memWritableFS) Create(name string) (WritableFile, error) {
+	m.files[name] = nil
+	return &memWritableFile{m: m, name: name}, nil
+}
+
+func (m *memWritableFS) Mkdir(name string, perm fs.FileMode) error {
+	m.files[name+"/.keep"] = nil
+	return nil
+}
+
+func (m *memWritableFS) Remove(name string) error {
+	if _, ok := m.files[name]; !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	delete(m.files, name)
+	return nil
+}
+
+func (m *memWritableFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.files[name] = append([]byte(nil), data...)
+	return nil
+}
+
+type memWritableFile struct {
+	m    *memWritableFS
+	name string
+}
+
+func (f *memWritableFile) Write(p []byte) (int, error) {
+	f.m.files[f.name] = append(f.m.files[f.name], p...)
+	return len(p), nil
+}
+
+func (f *memWritableFile) Stat() (fs.FileInfo, error) { return fs.Stat(f.m, f.name) }
+func (f *memWritableFile) Read([]byte) (int, error)   { return 0, fs.ErrInvalid }
+func (f *memWritableFile) Close() error               { return nil }
+
+func TestExcludeFnWritable(t *testing.T) {
+	mem := newMemWritableFS()
+	mem.files["a"] = []byte("a")
+	mem.files["b/c"] = []byte("c")
+
+	wfs := ExcludeFnWritable(mem, func(path string) bool { return path == "b" })
+
+	if err := wfs.WriteFile("b/d", []byte("d"), 0o644); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("WriteFile(b/d) = %v, want fs.ErrNotExist", err)
+	}
+	if _, err := wfs.Create("b/e"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Create(b/e) = %v, want fs.ErrNotExist", err)
+	}
+	if err := wfs.Mkdir("b/f", 0o755); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Mkdir(b/f) = %v, want fs.ErrNotExist", err)
+	}
+	if err := wfs.Remove("b/c"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Remove(b/c) = %v, want fs.ErrNotExist", err)
+	}
+
+	if err := wfs.WriteFile("a", []byte("aa"), 0o644); err != nil {
+		t.Errorf("WriteFile(a) = %v, want nil", err)
+	}
+	data, err := fs.ReadFile(wfs, "a")
+	if err != nil || !bytes.Equal(data, []byte("aa")) {
+		t.Errorf("ReadFile(a) = %q, %v, want \"aa\", nil", data, err)
+	}
+
+	if _, err := fs.Stat(wfs, "b/c"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat(b/c) = %v, want fs.ErrNotExist", err)
+	}
+}